@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/yaml"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// fullClusterProfile returns a ClusterProfile with every field set.
+func fullClusterProfile() *ClusterProfile {
+	// The wire format of metav1.Time has a precision of one second.
+	now := metav1.NewTime(time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC))
+	return &ClusterProfile{
+		TypeMeta: metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "ClusterProfile"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fleet",
+			Name:      "cluster-a",
+			Labels:    map[string]string{LabelClusterManagerKey: "manager"},
+		},
+		Spec: ClusterProfileSpec{
+			DisplayName:    "Cluster A",
+			ClusterManager: ClusterManager{Name: "manager"},
+			NetworkEndpoint: &NetworkEndpoint{
+				Host:          "fd00::1",
+				Port:          6443,
+				TLSServerName: "kubernetes",
+			},
+			CACertificateBundle:  []byte("-----BEGIN CERTIFICATE-----\n\x00\xff\n-----END CERTIFICATE-----\n"),
+			CustomRequestHeaders: map[string]string{"X-Audit-Id": "audit"},
+			OIDCIssuerURL:        "https://issuer.example.com",
+			OIDCClientID:         "client",
+			ProxyConfig:          &ProxyConfig{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://proxy:3128", NoProxy: "internal"},
+			OwnerCluster:         &ClusterProfileRef{Name: "hub"},
+			PrivateEndpoint:      true,
+			AgentRef: &AgentCoordinates{
+				Name:                "agent",
+				Namespace:           "agent-system",
+				ServiceURL:          "https://agent.example.com",
+				CACertificateBundle: []byte("agent-ca"),
+				TLSServerName:       "agent.agent-system.svc",
+			},
+			MaxAcceptableLatencyMs:     ptr[int32](200),
+			MaxConcurrentTokenRequests: ptr[int32](20),
+			ResourcePropagationLabels:  map[string]string{"team": "platform"},
+			AllowedNamespaces:          []string{"default", "team-a"},
+		},
+		Status: ClusterProfileStatus{
+			Conditions: []metav1.Condition{{
+				Type:               ClusterConditionControlPlaneHealthy,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 1,
+				LastTransitionTime: now,
+				Reason:             "Healthy",
+				Message:            "control plane is healthy",
+			}},
+			Version:    ClusterVersion{Kubernetes: "1.30.0"},
+			Properties: []Property{{Name: "nodes", Value: "3"}},
+			SupportedAPIGroups: []APIGroupSummary{
+				{Group: "", Versions: []string{"v1"}},
+				{Group: "apps", Versions: []string{"v1"}},
+			},
+			AgentLastHeartbeatTime: &now,
+			APIServerLatencyMs:     ptr[int64](42),
+			LastLatencySampleTime:  &now,
+		},
+	}
+}
+
+type codec struct {
+	name      string
+	marshal   func(interface{}) ([]byte, error)
+	unmarshal func([]byte, interface{}) error
+}
+
+var codecs = []codec{
+	{name: "JSON", marshal: json.Marshal, unmarshal: json.Unmarshal},
+	{name: "YAML", marshal: yaml.Marshal, unmarshal: func(data []byte, obj interface{}) error { return yaml.Unmarshal(data, obj) }},
+}
+
+func TestClusterProfileRoundTrip(t *testing.T) {
+	zeroPointers := &ClusterProfile{
+		Spec: ClusterProfileSpec{
+			NetworkEndpoint: &NetworkEndpoint{},
+			ProxyConfig:     &ProxyConfig{},
+		},
+		Status: ClusterProfileStatus{
+			APIServerLatencyMs: ptr[int64](0),
+		},
+	}
+	tests := []struct {
+		name    string
+		profile *ClusterProfile
+	}{
+		{name: "fully populated", profile: fullClusterProfile()},
+		{name: "empty", profile: &ClusterProfile{}},
+		{name: "zero values behind pointers", profile: zeroPointers},
+	}
+	for _, tt := range tests {
+		for _, c := range codecs {
+			t.Run(tt.name+"/"+c.name, func(t *testing.T) {
+				data, err := c.marshal(tt.profile)
+				if err != nil {
+					t.Fatalf("failed to marshal: %v", err)
+				}
+				got := &ClusterProfile{}
+				if err := c.unmarshal(data, got); err != nil {
+					t.Fatalf("failed to unmarshal %s: %v", data, err)
+				}
+				if !equality.Semantic.DeepEqual(got, tt.profile) {
+					t.Errorf("round trip through %s changed the object:\ngot:  %+v\nwant: %+v", data, got, tt.profile)
+				}
+			})
+		}
+	}
+}
+
+func TestClusterProfileWireFormat(t *testing.T) {
+	profile := fullClusterProfile()
+	data, err := json.Marshal(profile)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var wire struct {
+		Spec struct {
+			CACertificateBundle string `json:"caCertificateBundle"`
+		} `json:"spec"`
+		Status struct {
+			SupportedAPIGroups []map[string]interface{} `json:"supportedAPIGroups"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", data, err)
+	}
+	if want := base64.StdEncoding.EncodeToString(profile.Spec.CACertificateBundle); wire.Spec.CACertificateBundle != want {
+		t.Errorf("caCertificateBundle = %q, want base64 %q", wire.Spec.CACertificateBundle, want)
+	}
+	if len(wire.Status.SupportedAPIGroups) == 0 {
+		t.Fatalf("supportedAPIGroups is missing from %s", data)
+	}
+	if group, ok := wire.Status.SupportedAPIGroups[0]["group"]; !ok || group != "" {
+		t.Errorf("core group encoded as %v (present: %v), want an explicit empty group", group, ok)
+	}
+
+	// Unset optional fields must not appear on the wire.
+	data, err = json.Marshal(&ClusterProfile{})
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var empty struct {
+		Spec   map[string]interface{} `json:"spec"`
+		Status map[string]interface{} `json:"status"`
+	}
+	if err := json.Unmarshal(data, &empty); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", data, err)
+	}
+	for key := range empty.Spec {
+		if key != "clusterManager" {
+			t.Errorf("empty spec has field %q: %s", key, data)
+		}
+	}
+	for key := range empty.Status {
+		if key != "conditions" && key != "version" {
+			t.Errorf("empty status has field %q: %s", key, data)
+		}
+	}
+}
+
+// futureClusterProfile is a ClusterProfile as written by a newer version of the API,
+// with unknown fields at every level.
+const futureClusterProfile = `{
+	"apiVersion": "multicluster.x-k8s.io/v1alpha1",
+	"kind": "ClusterProfile",
+	"metadata": {"namespace": "fleet", "name": "cluster-a"},
+	"futureTopLevel": {"a": 1},
+	"spec": {
+		"displayName": "Cluster A",
+		"clusterManager": {"name": "manager", "futureManagerField": "x"},
+		"networkEndpoint": {"host": "cluster-a.example.com", "port": 6443, "futureEndpointField": true},
+		"allowedNamespaces": ["default"],
+		"futureSpecField": ["x", "y"]
+	},
+	"status": {
+		"conditions": [{
+			"type": "ControlPlaneHealthy",
+			"status": "True",
+			"lastTransitionTime": "2024-05-01T12:30:00Z",
+			"reason": "Healthy",
+			"message": "control plane is healthy",
+			"futureConditionField": "x"
+		}],
+		"supportedAPIGroups": [{"group": "apps", "versions": ["v1"], "futureGroupField": 1}],
+		"futureStatusField": {"nested": {"deeper": null}}
+	}
+}`
+
+func TestClusterProfileDecodeUnknownFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	decoders := []struct {
+		name   string
+		decode func([]byte) (*ClusterProfile, error)
+	}{
+		{
+			name: "encoding/json",
+			decode: func(data []byte) (*ClusterProfile, error) {
+				profile := &ClusterProfile{}
+				return profile, json.Unmarshal(data, profile)
+			},
+		},
+		{
+			name: "universal deserializer",
+			decode: func(data []byte) (*ClusterProfile, error) {
+				obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+				if err != nil {
+					return nil, err
+				}
+				profile, ok := obj.(*ClusterProfile)
+				if !ok {
+					return nil, fmt.Errorf("decoded %T, want *ClusterProfile", obj)
+				}
+				return profile, nil
+			},
+		},
+	}
+	for _, d := range decoders {
+		t.Run(d.name, func(t *testing.T) {
+			profile, err := d.decode([]byte(futureClusterProfile))
+			if err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+
+			if profile.Name != "cluster-a" || profile.Spec.DisplayName != "Cluster A" || profile.Spec.ClusterManager.Name != "manager" {
+				t.Errorf("known fields were not decoded: %+v", profile)
+			}
+			if e := profile.Spec.NetworkEndpoint; e == nil || e.Host != "cluster-a.example.com" || e.Port != 6443 {
+				t.Errorf("NetworkEndpoint = %+v, want the decoded endpoint", e)
+			}
+			if len(profile.Spec.AllowedNamespaces) != 1 || profile.Spec.AllowedNamespaces[0] != "default" {
+				t.Errorf("AllowedNamespaces = %v, want [default]", profile.Spec.AllowedNamespaces)
+			}
+			if c := meta.FindStatusCondition(profile.Status.Conditions, ClusterConditionControlPlaneHealthy); c == nil || c.Status != metav1.ConditionTrue {
+				t.Errorf("ControlPlaneHealthy condition = %+v, want status True", c)
+			}
+			if g := profile.Status.SupportedAPIGroups; len(g) != 1 || g[0].Group != "apps" {
+				t.Errorf("SupportedAPIGroups = %+v, want the apps group", g)
+			}
+
+			// The object remains usable with the current types, and unknown fields are
+			// dropped when it is encoded again.
+			SetCondition(&profile.Status.Conditions, metav1.Condition{
+				Type:               ClusterConditionHighLatency,
+				Status:             metav1.ConditionFalse,
+				Reason:             "Test",
+				LastTransitionTime: metav1.NewTime(time.Date(2024, 5, 1, 12, 31, 0, 0, time.UTC)),
+			})
+			data, err := json.Marshal(profile.DeepCopy())
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if strings.Contains(string(data), "future") {
+				t.Errorf("unknown fields were preserved: %s", data)
+			}
+			got := &ClusterProfile{}
+			if err := json.Unmarshal(data, got); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", data, err)
+			}
+			if !equality.Semantic.DeepEqual(got, profile) {
+				t.Errorf("re-encoding changed the object:\ngot:  %+v\nwant: %+v", got, profile)
+			}
+		})
+	}
+}
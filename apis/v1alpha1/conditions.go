@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SortConditions sorts conditions by Type, so that the order of conditions is stable
+// regardless of the order in which they were added.
+func SortConditions(conditions []metav1.Condition) {
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditions[i].Type < conditions[j].Type
+	})
+}
+
+// SetCondition adds or updates newCondition in conditions and keeps conditions sorted by Type.
+// LastTransitionTime is handled the same way as in meta.SetStatusCondition.
+// It returns true if conditions was changed.
+func SetCondition(conditions *[]metav1.Condition, newCondition metav1.Condition) (changed bool) {
+	if conditions == nil {
+		return false
+	}
+	changed = meta.SetStatusCondition(conditions, newCondition)
+	SortConditions(*conditions)
+	return changed
+}
+
+// ConditionsSemanticallyEqual reports whether a and b hold the same conditions, comparing
+// Type, Status, Reason and Message only. LastTransitionTime and ObservedGeneration are
+// ignored, as is the order of the conditions. Conditions sharing a Type are compared as
+// a multiset, so duplicates must appear the same number of times in both slices.
+func ConditionsSemanticallyEqual(a, b []metav1.Condition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	type conditionKey struct {
+		Type    string
+		Status  metav1.ConditionStatus
+		Reason  string
+		Message string
+	}
+	counts := make(map[conditionKey]int, len(a))
+	for _, c := range a {
+		counts[conditionKey{c.Type, c.Status, c.Reason, c.Message}]++
+	}
+	for _, c := range b {
+		key := conditionKey{c.Type, c.Status, c.Reason, c.Message}
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func conditionTypes(conditions []metav1.Condition) []string {
+	types := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		types = append(types, c.Type)
+	}
+	return types
+}
+
+func TestSetConditionKeepsOrder(t *testing.T) {
+	orders := [][]string{
+		{"C", "A", "B"},
+		{"B", "C", "A"},
+		{"A", "B", "C"},
+	}
+	for _, order := range orders {
+		var conditions []metav1.Condition
+		for _, conditionType := range order {
+			SetCondition(&conditions, metav1.Condition{Type: conditionType, Status: metav1.ConditionTrue, Reason: "Test"})
+		}
+		// Updating an existing condition must not move it.
+		SetCondition(&conditions, metav1.Condition{Type: "B", Status: metav1.ConditionFalse, Reason: "Test"})
+
+		got := conditionTypes(conditions)
+		want := []string{"A", "B", "C"}
+		if len(got) != len(want) {
+			t.Fatalf("insertion order %v: got types %v, want %v", order, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("insertion order %v: got types %v, want %v", order, got, want)
+			}
+		}
+	}
+}
+
+func TestSetConditionChanged(t *testing.T) {
+	var conditions []metav1.Condition
+	cond := metav1.Condition{Type: "A", Status: metav1.ConditionTrue, Reason: "Test"}
+
+	if !SetCondition(&conditions, cond) {
+		t.Errorf("adding a condition: got changed=false, want true")
+	}
+	if SetCondition(&conditions, cond) {
+		t.Errorf("setting an identical condition: got changed=true, want false")
+	}
+	cond.Status = metav1.ConditionFalse
+	if !SetCondition(&conditions, cond) {
+		t.Errorf("updating a condition: got changed=false, want true")
+	}
+}
+
+func TestSetConditionNil(t *testing.T) {
+	if SetCondition(nil, metav1.Condition{Type: "A", Status: metav1.ConditionTrue, Reason: "Test"}) {
+		t.Errorf("got changed=true for nil conditions, want false")
+	}
+}
+
+func TestConditionsSemanticallyEqual(t *testing.T) {
+	now := metav1.Now()
+	later := metav1.NewTime(now.Add(time.Second))
+	x := metav1.Condition{Type: "X", Status: metav1.ConditionTrue, Reason: "Test", Message: "x", LastTransitionTime: now, ObservedGeneration: 1}
+	y := metav1.Condition{Type: "Y", Status: metav1.ConditionFalse, Reason: "Test", Message: "y", LastTransitionTime: now, ObservedGeneration: 1}
+	xLater := x
+	xLater.LastTransitionTime = later
+	xLater.ObservedGeneration = 2
+	xFalse := x
+	xFalse.Status = metav1.ConditionFalse
+	xOtherReason := x
+	xOtherReason.Reason = "Other"
+	xOtherMessage := x
+	xOtherMessage.Message = "other"
+
+	tests := []struct {
+		name string
+		a, b []metav1.Condition
+		want bool
+	}{
+		{name: "both empty", a: nil, b: []metav1.Condition{}, want: true},
+		{name: "equal", a: []metav1.Condition{x, y}, b: []metav1.Condition{x, y}, want: true},
+		{name: "reordered", a: []metav1.Condition{x, y}, b: []metav1.Condition{y, x}, want: true},
+		{name: "volatile fields ignored", a: []metav1.Condition{x}, b: []metav1.Condition{xLater}, want: true},
+		{name: "different length", a: []metav1.Condition{x, y}, b: []metav1.Condition{x}, want: false},
+		{name: "different status", a: []metav1.Condition{x}, b: []metav1.Condition{xFalse}, want: false},
+		{name: "different reason", a: []metav1.Condition{x}, b: []metav1.Condition{xOtherReason}, want: false},
+		{name: "different message", a: []metav1.Condition{x}, b: []metav1.Condition{xOtherMessage}, want: false},
+		{name: "different type", a: []metav1.Condition{x}, b: []metav1.Condition{y}, want: false},
+		{name: "duplicate type against distinct types", a: []metav1.Condition{x, x}, b: []metav1.Condition{x, y}, want: false},
+		{name: "duplicate type against distinct types reversed", a: []metav1.Condition{x, y}, b: []metav1.Condition{x, x}, want: false},
+		{name: "duplicate type with different status", a: []metav1.Condition{x, x}, b: []metav1.Condition{x, xFalse}, want: false},
+		{name: "same duplicates", a: []metav1.Condition{x, xFalse}, b: []metav1.Condition{xFalse, x}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConditionsSemanticallyEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("ConditionsSemanticallyEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,12 @@ limitations under the License.
 */
 
 // Package v1alpha1 contains API Schema definitions for the multicluster.x-k8s.io v1alpha1 API group
+//
+// Unknown fields are ignored when decoding the types of this package, so that clients built
+// against an older version of the API can read objects written by a newer one. Unknown fields
+// are not preserved, though: an object decoded into these types and written back with an update
+// loses the fields the client does not know about. Such clients should change objects with
+// patches that only carry the fields they own.
 // +kubebuilder:object:generate=true
 // +groupName=multicluster.x-k8s.io
 package v1alpha1
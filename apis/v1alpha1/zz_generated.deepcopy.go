@@ -25,6 +25,46 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIGroupSummary) DeepCopyInto(out *APIGroupSummary) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIGroupSummary.
+func (in *APIGroupSummary) DeepCopy() *APIGroupSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(APIGroupSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentCoordinates) DeepCopyInto(out *AgentCoordinates) {
+	*out = *in
+	if in.CACertificateBundle != nil {
+		in, out := &in.CACertificateBundle, &out.CACertificateBundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentCoordinates.
+func (in *AgentCoordinates) DeepCopy() *AgentCoordinates {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentCoordinates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterManager) DeepCopyInto(out *ClusterManager) {
 	*out = *in
@@ -45,7 +85,7 @@ func (in *ClusterProfile) DeepCopyInto(out *ClusterProfile) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -99,10 +139,84 @@ func (in *ClusterProfileList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProfileRef) DeepCopyInto(out *ClusterProfileRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileRef.
+func (in *ClusterProfileRef) DeepCopy() *ClusterProfileRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProfileRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterProfileSpec) DeepCopyInto(out *ClusterProfileSpec) {
 	*out = *in
 	out.ClusterManager = in.ClusterManager
+	if in.NetworkEndpoint != nil {
+		in, out := &in.NetworkEndpoint, &out.NetworkEndpoint
+		*out = new(NetworkEndpoint)
+		**out = **in
+	}
+	if in.CACertificateBundle != nil {
+		in, out := &in.CACertificateBundle, &out.CACertificateBundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertificateSecretRef != nil {
+		in, out := &in.CACertificateSecretRef, &out.CACertificateSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.CustomRequestHeaders != nil {
+		in, out := &in.CustomRequestHeaders, &out.CustomRequestHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProxyConfig != nil {
+		in, out := &in.ProxyConfig, &out.ProxyConfig
+		*out = new(ProxyConfig)
+		**out = **in
+	}
+	if in.OwnerCluster != nil {
+		in, out := &in.OwnerCluster, &out.OwnerCluster
+		*out = new(ClusterProfileRef)
+		**out = **in
+	}
+	if in.AgentRef != nil {
+		in, out := &in.AgentRef, &out.AgentRef
+		*out = new(AgentCoordinates)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxAcceptableLatencyMs != nil {
+		in, out := &in.MaxAcceptableLatencyMs, &out.MaxAcceptableLatencyMs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentTokenRequests != nil {
+		in, out := &in.MaxConcurrentTokenRequests, &out.MaxConcurrentTokenRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourcePropagationLabels != nil {
+		in, out := &in.ResourcePropagationLabels, &out.ResourcePropagationLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileSpec.
@@ -131,6 +245,26 @@ func (in *ClusterProfileStatus) DeepCopyInto(out *ClusterProfileStatus) {
 		*out = make([]Property, len(*in))
 		copy(*out, *in)
 	}
+	if in.SupportedAPIGroups != nil {
+		in, out := &in.SupportedAPIGroups, &out.SupportedAPIGroups
+		*out = make([]APIGroupSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AgentLastHeartbeatTime != nil {
+		in, out := &in.AgentLastHeartbeatTime, &out.AgentLastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
+	if in.APIServerLatencyMs != nil {
+		in, out := &in.APIServerLatencyMs, &out.APIServerLatencyMs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastLatencySampleTime != nil {
+		in, out := &in.LastLatencySampleTime, &out.LastLatencySampleTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProfileStatus.
@@ -158,6 +292,21 @@ func (in *ClusterVersion) DeepCopy() *ClusterVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkEndpoint) DeepCopyInto(out *NetworkEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkEndpoint.
+func (in *NetworkEndpoint) DeepCopy() *NetworkEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Property) DeepCopyInto(out *Property) {
 	*out = *in
@@ -172,3 +321,33 @@ func (in *Property) DeepCopy() *Property {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRef) DeepCopyInto(out *SecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
+func (in *SecretRef) DeepCopy() *SecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package restconfig builds client configurations for clusters described by a ClusterProfile.
+package restconfig
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+// BuildRestConfigFromProfile synthesizes a *rest.Config for the cluster represented by profile.
+// The API server address is taken from the NetworkEndpoint of the profile, or from its AgentRef
+// if the profile has a private endpoint. The credentials are taken from secret, which follows
+// the layout of a service account token secret: the bearer token is read from the "token" key
+// and the optional CA bundle from the "ca.crt" key.
+// The CA bundle of the profile takes precedence over the one in secret: either the inline
+// CACertificateBundle, or the "ca.crt" key of caSecret, which must be the secret referenced by
+// CACertificateSecretRef. caSecret is required if and only if the profile sets CACertificateSecretRef.
+// For a private endpoint, the CA bundle and TLS server name of the agent are used instead of
+// those of the cluster, and the bearer token is sent to the agent.
+// CustomRequestHeaders of the profile are added to every request made with the returned config,
+// and ProxyConfig, if set, overrides the proxy environment variables for this cluster only.
+// Credentials in the proxy URLs are sent by the transport to the proxy in the
+// Proxy-Authorization header.
+func BuildRestConfigFromProfile(profile *v1alpha1.ClusterProfile, secret, caSecret *corev1.Secret) (*rest.Config, error) {
+	if profile == nil {
+		return nil, fmt.Errorf("cluster profile must not be nil")
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("secret must not be nil")
+	}
+	token, ok := secret.Data[corev1.ServiceAccountTokenKey]
+	if !ok || len(token) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", secret.Namespace, secret.Name, corev1.ServiceAccountTokenKey)
+	}
+	caData, err := caBundle(profile, secret, caSecret)
+	if err != nil {
+		return nil, err
+	}
+	host, tlsConfig, err := serverAddress(profile, caData)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &rest.Config{
+		Host:            host,
+		BearerToken:     string(token),
+		TLSClientConfig: tlsConfig,
+	}
+	if len(profile.Spec.CustomRequestHeaders) > 0 {
+		headers := make(http.Header, len(profile.Spec.CustomRequestHeaders))
+		for name, value := range profile.Spec.CustomRequestHeaders {
+			if !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+				return nil, fmt.Errorf("cluster profile %s/%s sets invalid request header %q", profile.Namespace, profile.Name, name)
+			}
+			if isReservedHeader(name) {
+				return nil, fmt.Errorf("cluster profile %s/%s sets reserved request header %q", profile.Namespace, profile.Name, name)
+			}
+			headers.Set(name, value)
+		}
+		config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &headerRoundTripper{headers: headers, delegate: rt}
+		})
+	}
+	if proxyConfig := profile.Spec.ProxyConfig; proxyConfig != nil {
+		config.Proxy = proxyFunc(proxyConfig)
+	}
+	return config, nil
+}
+
+// serverAddress returns the address to use for requests to the cluster of profile, and the TLS
+// configuration to verify it with. caData is the CA bundle of the cluster.
+// Clusters with a private endpoint are reached through the REST proxy of their agent, which is
+// verified with the CA bundle and server name of the agent instead of those of the cluster.
+func serverAddress(profile *v1alpha1.ClusterProfile, caData []byte) (string, rest.TLSClientConfig, error) {
+	if profile.Spec.PrivateEndpoint {
+		agent := profile.Spec.AgentRef
+		if agent == nil {
+			return "", rest.TLSClientConfig{}, fmt.Errorf("cluster profile %s/%s has a private endpoint but no agent", profile.Namespace, profile.Name)
+		}
+		if u, err := url.Parse(agent.ServiceURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return "", rest.TLSClientConfig{}, fmt.Errorf("cluster profile %s/%s has an agent with invalid service URL %q, an https URL is required",
+				profile.Namespace, profile.Name, agent.ServiceURL)
+		}
+		if len(agent.CACertificateBundle) > 0 {
+			if err := validateCABundle(agent.CACertificateBundle); err != nil {
+				return "", rest.TLSClientConfig{}, fmt.Errorf("cluster profile %s/%s has an agent with an invalid CA certificate bundle: %w",
+					profile.Namespace, profile.Name, err)
+			}
+		}
+		return agent.ServiceURL, rest.TLSClientConfig{ServerName: agent.TLSServerName, CAData: agent.CACertificateBundle}, nil
+	}
+	endpoint := profile.Spec.NetworkEndpoint
+	if endpoint == nil {
+		return "", rest.TLSClientConfig{}, fmt.Errorf("cluster profile %s/%s has no network endpoint", profile.Namespace, profile.Name)
+	}
+	host := "https://" + net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port)))
+	return host, rest.TLSClientConfig{ServerName: endpoint.TLSServerName, CAData: caData}, nil
+}
+
+// caBundle returns the CA bundle used to verify the serving certificate of the API server of
+// the cluster of profile. See BuildRestConfigFromProfile for the order of precedence.
+func caBundle(profile *v1alpha1.ClusterProfile, secret, caSecret *corev1.Secret) ([]byte, error) {
+	ref := profile.Spec.CACertificateSecretRef
+	switch {
+	case ref == nil && caSecret != nil:
+		return nil, fmt.Errorf("cluster profile %s/%s does not reference a CA secret, but secret %s/%s was given",
+			profile.Namespace, profile.Name, caSecret.Namespace, caSecret.Name)
+	case ref != nil && caSecret == nil:
+		return nil, fmt.Errorf("cluster profile %s/%s references CA secret %q, but no CA secret was given",
+			profile.Namespace, profile.Name, ref.Name)
+	case ref != nil:
+		if caSecret.Namespace != profile.Namespace || caSecret.Name != ref.Name {
+			return nil, fmt.Errorf("cluster profile %s/%s references CA secret %s/%s, but secret %s/%s was given",
+				profile.Namespace, profile.Name, profile.Namespace, ref.Name, caSecret.Namespace, caSecret.Name)
+		}
+		caData := caSecret.Data[corev1.ServiceAccountRootCAKey]
+		if len(caData) == 0 {
+			return nil, fmt.Errorf("secret %s/%s has no %q key", caSecret.Namespace, caSecret.Name, corev1.ServiceAccountRootCAKey)
+		}
+		if err := validateCABundle(caData); err != nil {
+			return nil, fmt.Errorf("secret %s/%s has an invalid CA certificate bundle: %w", caSecret.Namespace, caSecret.Name, err)
+		}
+		return caData, nil
+	}
+	if len(profile.Spec.CACertificateBundle) > 0 {
+		if err := validateCABundle(profile.Spec.CACertificateBundle); err != nil {
+			return nil, fmt.Errorf("cluster profile %s/%s has an invalid CA certificate bundle: %w", profile.Namespace, profile.Name, err)
+		}
+		return profile.Spec.CACertificateBundle, nil
+	}
+	return secret.Data[corev1.ServiceAccountRootCAKey], nil
+}
+
+// proxyFunc returns a proxy function that applies the proxy environment variables,
+// with the non-empty fields of proxyConfig taking precedence.
+func proxyFunc(proxyConfig *v1alpha1.ProxyConfig) func(*http.Request) (*url.URL, error) {
+	cfg := httpproxy.FromEnvironment()
+	if proxyConfig.HTTPProxy != "" {
+		cfg.HTTPProxy = proxyConfig.HTTPProxy
+	}
+	if proxyConfig.HTTPSProxy != "" {
+		cfg.HTTPSProxy = proxyConfig.HTTPSProxy
+	}
+	if proxyConfig.NoProxy != "" {
+		cfg.NoProxy = proxyConfig.NoProxy
+	}
+	proxy := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
+
+// isReservedHeader reports whether name is a header that carries the identity of the caller.
+// Such headers are set by client-go from the credentials of the config, or by the transport
+// from the proxy URL, and must not be overridden by a ClusterProfile.
+func isReservedHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	return name == "Authorization" || name == "Proxy-Authorization" || strings.HasPrefix(name, "Impersonate-")
+}
+
+// headerRoundTripper adds a fixed set of headers to every request before delegating it.
+// The headers are checked by BuildRestConfigFromProfile.
+type headerRoundTripper struct {
+	headers  http.Header
+	delegate http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given.
+	req = req.Clone(req.Context())
+	for name, values := range rt.headers {
+		req.Header[name] = values
+	}
+	return rt.delegate.RoundTrip(req)
+}
+
+// validateCABundle checks that data consists of one or more PEM-encoded X.509 certificates,
+// optionally separated by whitespace. Any other data in the bundle is an error.
+func validateCABundle(data []byte) error {
+	remaining := bytes.TrimSpace(data)
+	if len(remaining) == 0 {
+		return fmt.Errorf("no PEM-encoded certificate found")
+	}
+	for len(remaining) > 0 {
+		if !bytes.HasPrefix(remaining, pemBegin) {
+			return fmt.Errorf("unexpected data outside of a PEM block")
+		}
+		block, next := pem.Decode(remaining)
+		// pem.Decode skips malformed blocks, so make sure it decoded the block we are looking at.
+		if block == nil || bytes.Count(remaining[:len(remaining)-len(next)], pemBegin) != 1 {
+			return fmt.Errorf("malformed PEM block")
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block of type %q", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("invalid certificate: %w", err)
+		}
+		remaining = bytes.TrimSpace(next)
+	}
+	return nil
+}
+
+var pemBegin = []byte("-----BEGIN ")